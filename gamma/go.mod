@@ -1,3 +1,3 @@
-module gamma
+module github.com/andrewchu16/bigraytracer/gamma
 
 go 1.23.4
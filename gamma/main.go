@@ -1,8 +1,14 @@
 package main
 
 import (
-	"gamma/renderer"
-	"gamma/scene"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"github.com/andrewchu16/bigraytracer/gamma/renderer"
+	"github.com/andrewchu16/bigraytracer/gamma/scene"
+	"os"
+	"path/filepath"
+	"strings"
 )
 
 const (
@@ -12,11 +18,72 @@ const (
 	IMG_FORMAT = renderer.PNG
 )
 
+// dryRunReport is the JSON document printed by -dry-run.
+type dryRunReport struct {
+	Output   string                         `json:"output"`
+	Format   renderer.SupportedImageFormats `json:"format"`
+	Settings renderer.Settings              `json:"settings"`
+	Scene    sceneSummary                   `json:"scene"`
+}
+
+type sceneSummary struct {
+	Hash string `json:"hash"`
+}
+
 func main() {
+	dryRun := flag.Bool("dry-run", false, "print the effective settings and scene summary as JSON without rendering")
+	version := flag.Bool("version", false, "print the engine version and exit")
+	capabilities := flag.Bool("capabilities", false, "print the engine capabilities as JSON and exit")
+	manifest := flag.Bool("manifest", false, "write a provenance manifest JSON next to the exported image")
+	flag.Parse()
+
+	if *version {
+		fmt.Println("gamma", renderer.Version())
+		return
+	}
+
+	if *capabilities {
+		writeJSON(renderer.Capabilities())
+		return
+	}
+
 	r := renderer.NewRenderer(IMG_WIDTH, IMG_HEIGHT)
 	s := scene.NewScene()
 
 	r.SetScene(s)
+
+	if *dryRun {
+		report := dryRunReport{
+			Output:   IMG_NAME,
+			Format:   IMG_FORMAT,
+			Settings: r.Settings(),
+			Scene:    sceneSummary{Hash: s.Hash()},
+		}
+
+		writeJSON(report)
+		return
+	}
+
 	r.Render()
-	r.Export(IMG_NAME, IMG_FORMAT)
+	if err := r.Export(IMG_NAME, IMG_FORMAT); err != nil {
+		os.Exit(1)
+	}
+
+	if *manifest {
+		manifestName := strings.TrimSuffix(IMG_NAME, filepath.Ext(IMG_NAME)) + ".manifest.json"
+		if err := r.WriteManifest(manifestName); err != nil {
+			fmt.Fprintln(os.Stderr, "Error writing manifest:", err)
+			os.Exit(1)
+		}
+	}
+}
+
+// writeJSON prints v to standard output as indented JSON, exiting on failure.
+func writeJSON(v any) {
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(v); err != nil {
+		fmt.Fprintln(os.Stderr, "Error writing JSON:", err)
+		os.Exit(1)
+	}
 }
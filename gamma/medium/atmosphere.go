@@ -0,0 +1,191 @@
+package medium
+
+import (
+	"github.com/andrewchu16/bigraytracer/gamma/geometry"
+	"math"
+)
+
+const (
+	// viewSteps is the number of samples taken along a view ray when integrating
+	// through the atmosphere.
+	viewSteps = 32
+	// lightSteps is the number of samples taken along the path towards the sun
+	// from each view sample.
+	lightSteps = 8
+)
+
+// Atmosphere is a planetary atmosphere made of molecules, which scatter light
+// following the Rayleigh phase function, and aerosols, which scatter following
+// Henyey–Greenstein. The density of each falls off exponentially with altitude.
+//
+// Distances are in meters and coefficients are per meter. Each RGB channel of
+// a geometry.Vec3 coefficient is stored in its X, Y, and Z components. +Y
+// points up, and the planet is centered below the scene origin so that the
+// plane y = 0 lies at sea level.
+type Atmosphere struct {
+	PlanetRadius     float64
+	AtmosphereRadius float64
+
+	// RayleighScattering is the molecular scattering coefficient at sea level.
+	RayleighScattering  geometry.Vec3
+	RayleighScaleHeight float64
+
+	// MieScattering and MieAbsorption are the aerosol coefficients at sea level.
+	MieScattering  float64
+	MieAbsorption  float64
+	MieScaleHeight float64
+	MieAsymmetry   float64
+}
+
+// NewEarthAtmosphere creates and returns an atmosphere with the parameters of a
+// clear day on Earth.
+func NewEarthAtmosphere() Atmosphere {
+	return Atmosphere{
+		PlanetRadius:        6360e3,
+		AtmosphereRadius:    6460e3,
+		RayleighScattering:  geometry.NewVec3(5.802e-6, 13.558e-6, 33.1e-6),
+		RayleighScaleHeight: 8000,
+		MieScattering:       3.996e-6,
+		MieAbsorption:       4.4e-6,
+		MieScaleHeight:      1200,
+		MieAsymmetry:        0.8,
+	}
+}
+
+// Density returns the densities of molecules and aerosols at the given altitude,
+// relative to their densities at sea level.
+func (a Atmosphere) Density(altitude float64) (rayleigh, mie float64) {
+	return math.Exp(-altitude / a.RayleighScaleHeight), math.Exp(-altitude / a.MieScaleHeight)
+}
+
+// Extinction returns the extinction coefficient of each color channel at the given altitude.
+func (a Atmosphere) Extinction(altitude float64) geometry.Vec3 {
+	rayleigh, mie := a.Density(altitude)
+
+	extinction := geometry.Mul(a.RayleighScattering, rayleigh)
+	aerosols := (a.MieScattering + a.MieAbsorption) * mie
+	extinction.Add(geometry.NewVec3(aerosols, aerosols, aerosols))
+	return extinction
+}
+
+// OpticalDepth returns the optical depth of each color channel along the segment
+// starting at origin, heading in the unit direction dir, with the given length.
+func (a Atmosphere) OpticalDepth(origin, dir geometry.Vec3, distance float64) geometry.Vec3 {
+	return a.opticalDepth(origin, dir, distance, viewSteps)
+}
+
+// Transmittance returns the fraction of light of each color channel that passes
+// unscattered along the segment starting at origin, heading in the unit direction
+// dir, with the given length.
+func (a Atmosphere) Transmittance(origin, dir geometry.Vec3, distance float64) geometry.Vec3 {
+	return expNeg(a.OpticalDepth(origin, dir, distance))
+}
+
+// InScattering returns the radiance scattered towards origin by the atmosphere
+// along the segment starting at origin, heading in the unit direction dir, with
+// the given length. It accounts for single scattering of sunlight arriving from
+// the unit direction sunDir, per unit of sun irradiance.
+//
+// Together with Transmittance it gives aerial perspective: a surface at the end
+// of the segment is seen as its radiance times the transmittance plus the
+// in-scattered radiance times the sun irradiance.
+func (a Atmosphere) InScattering(origin, dir, sunDir geometry.Vec3, distance float64) geometry.Vec3 {
+	cosTheta := geometry.Dot(dir, sunDir)
+	rayleighPhase := NewRayleigh().Eval(cosTheta)
+	miePhase := NewHenyeyGreenstein(a.MieAsymmetry).Eval(cosTheta)
+
+	ds := distance / viewSteps
+	center := a.center()
+
+	var result, viewDepth geometry.Vec3
+	for i := range viewSteps {
+		p := geometry.Add(origin, geometry.Mul(dir, (float64(i)+0.5)*ds))
+		altitude := a.altitude(p)
+		extinction := a.Extinction(altitude)
+
+		// Optical depth from origin to the middle of this step.
+		viewDepth.Add(geometry.Mul(extinction, ds/2))
+
+		if near, _, hit := raySphere(p, sunDir, center, a.PlanetRadius); !hit || near < 0 {
+			_, sunDistance, _ := raySphere(p, sunDir, center, a.AtmosphereRadius)
+			depth := geometry.Add(viewDepth, a.opticalDepth(p, sunDir, sunDistance, lightSteps))
+
+			rayleigh, mie := a.Density(altitude)
+			scattering := geometry.Mul(a.RayleighScattering, rayleigh*rayleighPhase)
+			aerosols := a.MieScattering * mie * miePhase
+			scattering.Add(geometry.NewVec3(aerosols, aerosols, aerosols))
+
+			result.Add(geometry.Mul(mulVec(expNeg(depth), scattering), ds))
+		}
+
+		viewDepth.Add(geometry.Mul(extinction, ds/2))
+	}
+
+	return result
+}
+
+// SkyRadiance returns the radiance of the sky seen from origin in the unit
+// direction dir, per unit of sun irradiance arriving from the unit direction
+// sunDir. Rays that hit the planet only include the atmosphere in front of the
+// ground. It can be used as the background for rays that escape the scene.
+func (a Atmosphere) SkyRadiance(origin, dir, sunDir geometry.Vec3) geometry.Vec3 {
+	center := a.center()
+
+	near, far, hit := raySphere(origin, dir, center, a.AtmosphereRadius)
+	if !hit || far < 0 {
+		return geometry.ZERO_VEC3
+	}
+	near = math.Max(near, 0)
+
+	if ground, _, hit := raySphere(origin, dir, center, a.PlanetRadius); hit && ground > 0 {
+		far = math.Min(far, ground)
+	}
+
+	start := geometry.Add(origin, geometry.Mul(dir, near))
+	return a.InScattering(start, dir, sunDir, far-near)
+}
+
+func (a Atmosphere) opticalDepth(origin, dir geometry.Vec3, distance float64, steps int) geometry.Vec3 {
+	ds := distance / float64(steps)
+
+	var depth geometry.Vec3
+	for i := range steps {
+		p := geometry.Add(origin, geometry.Mul(dir, (float64(i)+0.5)*ds))
+		depth.Add(geometry.Mul(a.Extinction(a.altitude(p)), ds))
+	}
+	return depth
+}
+
+func (a Atmosphere) center() geometry.Vec3 {
+	return geometry.NewVec3(0, -a.PlanetRadius, 0)
+}
+
+func (a Atmosphere) altitude(p geometry.Vec3) float64 {
+	return geometry.Length(geometry.Sub(p, a.center())) - a.PlanetRadius
+}
+
+// raySphere returns the distances along the ray with the unit direction dir to
+// where it enters and leaves the sphere. hit is false if the ray misses it.
+func raySphere(origin, dir, center geometry.Vec3, radius float64) (near, far float64, hit bool) {
+	oc := geometry.Sub(origin, center)
+	b := geometry.Dot(oc, dir)
+	c := geometry.Dot(oc, oc) - radius*radius
+
+	discriminant := b*b - c
+	if discriminant < 0 {
+		return 0, 0, false
+	}
+
+	root := math.Sqrt(discriminant)
+	return -b - root, -b + root, true
+}
+
+// mulVec returns the component-wise product of two vectors.
+func mulVec(v1, v2 geometry.Vec3) geometry.Vec3 {
+	return geometry.NewVec3(v1.X*v2.X, v1.Y*v2.Y, v1.Z*v2.Z)
+}
+
+// expNeg returns e raised to the negated value of each component.
+func expNeg(v geometry.Vec3) geometry.Vec3 {
+	return geometry.NewVec3(math.Exp(-v.X), math.Exp(-v.Y), math.Exp(-v.Z))
+}
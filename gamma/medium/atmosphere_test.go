@@ -0,0 +1,95 @@
+package medium
+
+import (
+	"github.com/andrewchu16/bigraytracer/gamma/geometry"
+	"math"
+	"testing"
+)
+
+func TestAtmosphereDensityFalloff(t *testing.T) {
+	a := NewEarthAtmosphere()
+
+	rayleigh, mie := a.Density(0)
+	if rayleigh != 1 || mie != 1 {
+		t.Errorf("Density(0) = %f, %f; want 1, 1", rayleigh, mie)
+	}
+
+	rayleigh, _ = a.Density(a.RayleighScaleHeight)
+	if math.Abs(rayleigh-1/math.E) > 1e-12 {
+		t.Errorf("Rayleigh density at its scale height = %f; want %f", rayleigh, 1/math.E)
+	}
+
+	_, mie = a.Density(a.MieScaleHeight)
+	if math.Abs(mie-1/math.E) > 1e-12 {
+		t.Errorf("Mie density at its scale height = %f; want %f", mie, 1/math.E)
+	}
+}
+
+func TestAtmosphereTransmittanceAtSeaLevel(t *testing.T) {
+	a := NewEarthAtmosphere()
+
+	// Over a short horizontal path the altitude barely changes, so the
+	// transmittance follows Beer–Lambert with the sea level extinction.
+	const distance = 1000
+	result := a.Transmittance(geometry.ZERO_VEC3, geometry.UNIT_X, distance)
+	extinction := a.Extinction(0)
+	expected := geometry.NewVec3(
+		math.Exp(-extinction.X*distance),
+		math.Exp(-extinction.Y*distance),
+		math.Exp(-extinction.Z*distance),
+	)
+
+	if geometry.Length(geometry.Sub(result, expected)) > 1e-6 {
+		t.Errorf("Transmittance over %d m = %v; want %v", distance, result, expected)
+	}
+}
+
+func TestAtmosphereSunsetIsRed(t *testing.T) {
+	a := NewEarthAtmosphere()
+
+	// Sunlight crossing the atmosphere along the horizon loses more blue than red.
+	origin := geometry.NewVec3(0, 1, 0)
+	_, far, _ := raySphere(origin, geometry.UNIT_X, a.center(), a.AtmosphereRadius)
+	result := a.Transmittance(origin, geometry.UNIT_X, far)
+
+	if !(result.X > result.Y && result.Y > result.Z) {
+		t.Errorf("Transmittance towards the horizon = %v; want red > green > blue", result)
+	}
+}
+
+func TestAtmosphereSkyIsBlue(t *testing.T) {
+	a := NewEarthAtmosphere()
+
+	sunDir := geometry.NewVec3(0, 1, 1).Normal()
+	result := a.SkyRadiance(geometry.NewVec3(0, 1, 0), geometry.UNIT_Y, sunDir)
+
+	if !(result.Z > result.Y && result.Y > result.X && result.X > 0) {
+		t.Errorf("Sky radiance at the zenith = %v; want blue > green > red > 0", result)
+	}
+}
+
+func TestAtmosphereNight(t *testing.T) {
+	a := NewEarthAtmosphere()
+
+	// With the sun directly below, the planet shadows the whole view ray.
+	result := a.SkyRadiance(geometry.NewVec3(0, 1, 0), geometry.UNIT_Y, geometry.UNIT_Y.Neg())
+
+	if result != geometry.ZERO_VEC3 {
+		t.Errorf("Sky radiance with the sun below = %v; want %v", result, geometry.ZERO_VEC3)
+	}
+}
+
+func TestAtmosphereFromSpace(t *testing.T) {
+	a := NewEarthAtmosphere()
+	sunDir := geometry.UNIT_Y
+
+	// Looking away from the planet from outside the atmosphere shows nothing,
+	// while looking down through it shows the lit atmosphere.
+	origin := geometry.NewVec3(0, 200e3, 0)
+	if result := a.SkyRadiance(origin, geometry.UNIT_Y, sunDir); result != geometry.ZERO_VEC3 {
+		t.Errorf("Sky radiance looking into space = %v; want %v", result, geometry.ZERO_VEC3)
+	}
+	if result := a.SkyRadiance(origin, geometry.UNIT_Y.Neg(), sunDir); result.Z <= 0 {
+		t.Errorf("Sky radiance looking down at the planet = %v; want positive", result)
+	}
+}
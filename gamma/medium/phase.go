@@ -0,0 +1,133 @@
+// Package medium implements scattering models for participating media.
+//
+// It provides phase functions, which describe how light traveling through a
+// medium is redistributed over directions at a scattering event. Every phase
+// function is normalized over the sphere and can be both evaluated and
+// importance sampled.
+package medium
+
+import (
+	"github.com/andrewchu16/bigraytracer/gamma/geometry"
+	"math"
+)
+
+// PhaseFunction describes the angular distribution of light scattered in a medium.
+//
+// Angles are measured between the direction the light was traveling before the
+// scattering event and the direction it travels afterwards, so a cosine of 1
+// means the light continued straight ahead.
+type PhaseFunction interface {
+	// Eval returns the probability density of scattering by the angle whose cosine is given.
+	Eval(cosTheta float64) float64
+
+	// Sample returns a scattered direction for light traveling along dir, distributed
+	// according to Eval. The values u1 and u2 must be uniform random numbers in [0, 1).
+	Sample(dir geometry.Vec3, u1, u2 float64) geometry.Vec3
+}
+
+// Isotropic scatters light uniformly in all directions.
+type Isotropic struct{}
+
+// NewIsotropic creates and returns an isotropic phase function.
+func NewIsotropic() Isotropic {
+	return Isotropic{}
+}
+
+// Eval returns the constant density 1/(4π).
+func (p Isotropic) Eval(cosTheta float64) float64 {
+	return 1 / (4 * math.Pi)
+}
+
+// Sample returns a direction chosen uniformly over the sphere.
+func (p Isotropic) Sample(dir geometry.Vec3, u1, u2 float64) geometry.Vec3 {
+	return scatter(dir, 1-2*u1, u2)
+}
+
+// HenyeyGreenstein is the Henyey–Greenstein phase function. Its asymmetry
+// parameter lies in (-1, 1): positive values favor forward scattering, negative
+// values favor back scattering, and zero is isotropic. The zero value is isotropic.
+type HenyeyGreenstein struct {
+	g float64
+}
+
+// NewHenyeyGreenstein creates and returns a Henyey–Greenstein phase function with
+// asymmetry parameter g, clamped to the open interval (-1, 1).
+func NewHenyeyGreenstein(g float64) HenyeyGreenstein {
+	const limit = 0.999
+	return HenyeyGreenstein{math.Max(-limit, math.Min(limit, g))}
+}
+
+// G returns the asymmetry parameter of the phase function.
+func (p HenyeyGreenstein) G() float64 {
+	return p.g
+}
+
+// Eval returns the Henyey–Greenstein density for the given scattering angle.
+func (p HenyeyGreenstein) Eval(cosTheta float64) float64 {
+	g := p.g
+	denom := 1 + g*g - 2*g*cosTheta
+	return (1 - g*g) / (4 * math.Pi * denom * math.Sqrt(denom))
+}
+
+// Sample returns a direction distributed according to the Henyey–Greenstein density.
+func (p HenyeyGreenstein) Sample(dir geometry.Vec3, u1, u2 float64) geometry.Vec3 {
+	g := p.g
+
+	var cosTheta float64
+	if math.Abs(g) < 1e-3 {
+		cosTheta = 1 - 2*u1
+	} else {
+		s := (1 - g*g) / (1 - g + 2*g*u1)
+		cosTheta = (1 + g*g - s*s) / (2 * g)
+	}
+
+	return scatter(dir, cosTheta, u2)
+}
+
+// Rayleigh is the phase function for scattering by particles much smaller than
+// the wavelength of light, such as the molecules that make the sky blue.
+type Rayleigh struct{}
+
+// NewRayleigh creates and returns a Rayleigh phase function.
+func NewRayleigh() Rayleigh {
+	return Rayleigh{}
+}
+
+// Eval returns the Rayleigh density 3/(16π) * (1 + cos²θ).
+func (p Rayleigh) Eval(cosTheta float64) float64 {
+	return 3 / (16 * math.Pi) * (1 + cosTheta*cosTheta)
+}
+
+// Sample returns a direction distributed according to the Rayleigh density.
+func (p Rayleigh) Sample(dir geometry.Vec3, u1, u2 float64) geometry.Vec3 {
+	// Inverting the CDF gives the depressed cubic μ³ + 3μ - z = 0 with z = 8u - 4,
+	// which has exactly one real root.
+	z := 8*u1 - 4
+	root := math.Sqrt(z*z/4 + 1)
+	cosTheta := math.Cbrt(z/2+root) + math.Cbrt(z/2-root)
+
+	return scatter(dir, math.Max(-1, math.Min(1, cosTheta)), u2)
+}
+
+// scatter returns the unit vector making an angle of acos(cosTheta) with dir,
+// rotated about dir by the azimuth 2π*u.
+func scatter(dir geometry.Vec3, cosTheta, u float64) geometry.Vec3 {
+	w := dir.Normal()
+
+	// Build an orthonormal basis around w, picking the helper axis that is
+	// least parallel to it.
+	helper := geometry.UNIT_X
+	if math.Abs(w.X) > 0.9 {
+		helper = geometry.UNIT_Y
+	}
+	v := w.Cross(helper).Normal()
+	t := w.Cross(v)
+
+	sinTheta := math.Sqrt(math.Max(0, 1-cosTheta*cosTheta))
+	phi := 2 * math.Pi * u
+
+	d := geometry.Mul(w, cosTheta)
+	d.Add(geometry.Mul(v, sinTheta*math.Cos(phi)))
+	d.Add(geometry.Mul(t, sinTheta*math.Sin(phi)))
+	return d
+}
@@ -0,0 +1,131 @@
+package medium
+
+import (
+	"github.com/andrewchu16/bigraytracer/gamma/geometry"
+	"math"
+	"testing"
+)
+
+// integrate numerically integrates the phase function over the sphere.
+func integrate(p PhaseFunction) float64 {
+	const steps = 100000
+	sum := 0.0
+	for i := range steps {
+		cosTheta := -1 + 2*(float64(i)+0.5)/steps
+		sum += p.Eval(cosTheta)
+	}
+	return sum * 2 / steps * 2 * math.Pi
+}
+
+// sampleMoments returns the mean of cos θ and cos² θ over a stratified grid of
+// samples drawn from p around dir.
+func sampleMoments(p PhaseFunction, dir geometry.Vec3) (mean, meanSqr float64) {
+	const n = 1000
+	const m = 100
+	for i := range n {
+		for j := range m {
+			u1 := (float64(i) + 0.5) / n
+			u2 := (float64(j) + 0.5) / m
+			cosTheta := geometry.Dot(p.Sample(dir, u1, u2), dir)
+			mean += cosTheta
+			meanSqr += cosTheta * cosTheta
+		}
+	}
+	return mean / (n * m), meanSqr / (n * m)
+}
+
+func TestPhaseFunctionsNormalized(t *testing.T) {
+	phases := map[string]PhaseFunction{
+		"Isotropic": NewIsotropic(),
+		"HG(0.8)":   NewHenyeyGreenstein(0.8),
+		"HG(-0.5)":  NewHenyeyGreenstein(-0.5),
+		"Rayleigh":  NewRayleigh(),
+	}
+
+	for name, p := range phases {
+		result := integrate(p)
+		if math.Abs(result-1) > 1e-3 {
+			t.Errorf("Integral of %s over the sphere = %f; want 1", name, result)
+		}
+	}
+}
+
+func TestHenyeyGreensteinSampleMeanCosine(t *testing.T) {
+	dir := geometry.NewVec3(0, 0, 1)
+
+	for _, g := range []float64{-0.7, 0, 0.3, 0.9} {
+		mean, _ := sampleMoments(NewHenyeyGreenstein(g), dir)
+
+		// The mean cosine of the Henyey–Greenstein distribution is g.
+		if math.Abs(mean-g) > 1e-2 {
+			t.Errorf("Mean cosine of HG(%f) samples = %f; want %f", g, mean, g)
+		}
+	}
+}
+
+func TestRayleighSampleRange(t *testing.T) {
+	dir := geometry.NewVec3(1, 2, 3).Normal()
+	p := NewRayleigh()
+
+	for _, u := range []float64{0, 0.25, 0.5, 0.75, 0.999} {
+		d := p.Sample(dir, u, 0.3)
+		if !d.IsNormalized() {
+			t.Errorf("Rayleigh sample %v for u = %f is not normalized", d, u)
+		}
+	}
+
+	forward := p.Sample(dir, 0.999999, 0)
+	if geometry.Dot(forward, dir) < 0.99 {
+		t.Errorf("Rayleigh sample for u near 1 = %v; want close to %v", forward, dir)
+	}
+}
+
+func TestRayleighSampleDistribution(t *testing.T) {
+	dir := geometry.NewVec3(0, 1, 0)
+	mean, meanSqr := sampleMoments(NewRayleigh(), dir)
+
+	// Rayleigh scattering is symmetric, with E[cos²θ] = 2/5.
+	if math.Abs(mean) > 1e-3 {
+		t.Errorf("Mean cosine of Rayleigh samples = %f; want 0", mean)
+	}
+	if math.Abs(meanSqr-0.4) > 1e-3 {
+		t.Errorf("Mean squared cosine of Rayleigh samples = %f; want 0.4", meanSqr)
+	}
+}
+
+func TestIsotropicSampleDistribution(t *testing.T) {
+	dir := geometry.NewVec3(1, -2, 0.5).Normal()
+	p := NewIsotropic()
+
+	for _, u := range []float64{0, 0.3, 0.999} {
+		d := p.Sample(dir, u, 0.7)
+		if !d.IsNormalized() {
+			t.Errorf("Isotropic sample %v for u = %f is not normalized", d, u)
+		}
+	}
+
+	// Uniform directions over the sphere have E[cos θ] = 0 and E[cos²θ] = 1/3.
+	mean, meanSqr := sampleMoments(p, dir)
+	if math.Abs(mean) > 1e-3 {
+		t.Errorf("Mean cosine of isotropic samples = %f; want 0", mean)
+	}
+	if math.Abs(meanSqr-1.0/3) > 1e-3 {
+		t.Errorf("Mean squared cosine of isotropic samples = %f; want %f", meanSqr, 1.0/3)
+	}
+}
+
+func TestHenyeyGreensteinZeroValue(t *testing.T) {
+	var p HenyeyGreenstein
+
+	if p.G() != 0 {
+		t.Errorf("G() of zero value = %f; want 0", p.G())
+	}
+	if result := p.Eval(1); math.Abs(result-NewIsotropic().Eval(1)) > 1e-12 {
+		t.Errorf("Eval(1) of zero value = %f; want %f", result, NewIsotropic().Eval(1))
+	}
+
+	clamped := NewHenyeyGreenstein(1)
+	if result := clamped.Eval(1); math.IsNaN(result) || math.IsInf(result, 0) {
+		t.Errorf("Eval(1) of HG(1) = %f; want a finite value", result)
+	}
+}
@@ -0,0 +1,96 @@
+package renderer
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"runtime"
+	"time"
+)
+
+// Manifest records how an exported image was produced, so renders can be audited
+// for reproducibility and deduplicated by their inputs and outputs.
+type Manifest struct {
+	EngineVersion string                `json:"engineVersion"`
+	CreatedAt     time.Time             `json:"createdAt"`
+	Output        string                `json:"output"`
+	OutputSHA256  string                `json:"outputSha256"`
+	Format        SupportedImageFormats `json:"format"`
+	SceneHash     string                `json:"sceneHash"`
+	AssetHashes   map[string]string     `json:"assetHashes"`
+	Settings      Settings              `json:"settings"`
+	RenderSeconds float64               `json:"renderSeconds"`
+	Machine       MachineInfo           `json:"machine"`
+}
+
+// MachineInfo describes the machine an image was rendered on.
+type MachineInfo struct {
+	Hostname  string `json:"hostname"`
+	OS        string `json:"os"`
+	Arch      string `json:"arch"`
+	NumCPU    int    `json:"numCPU"`
+	GoVersion string `json:"goVersion"`
+}
+
+// WriteManifest writes a JSON manifest describing the image most recently written
+// by Export. The checksum is computed from the image file as it exists on disk.
+func (r *Renderer) WriteManifest(manifestFilename string) error {
+	if !r.rendered {
+		return errors.New("cannot write manifest before rendering")
+	}
+	if r.exportedFilename == "" {
+		return errors.New("cannot write manifest before exporting")
+	}
+
+	checksum, err := fileSHA256(r.exportedFilename)
+	if err != nil {
+		return err
+	}
+
+	hostname, _ := os.Hostname()
+
+	manifest := Manifest{
+		EngineVersion: engineVersion,
+		CreatedAt:     time.Now().UTC(),
+		Output:        r.exportedFilename,
+		OutputSHA256:  checksum,
+		Format:        r.exportedFormat,
+		SceneHash:     r.sceneHash,
+		AssetHashes:   map[string]string{}, // scenes do not reference external assets yet
+		Settings:      r.Settings(),
+		RenderSeconds: r.renderTime.Seconds(),
+		Machine: MachineInfo{
+			Hostname:  hostname,
+			OS:        runtime.GOOS,
+			Arch:      runtime.GOARCH,
+			NumCPU:    runtime.NumCPU(),
+			GoVersion: runtime.Version(),
+		},
+	}
+
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(manifestFilename, append(data, '\n'), 0644)
+}
+
+// fileSHA256 returns the hex-encoded SHA-256 digest of the named file.
+func fileSHA256(filename string) (string, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, file); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
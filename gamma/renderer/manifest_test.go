@@ -0,0 +1,142 @@
+package renderer
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"github.com/andrewchu16/bigraytracer/gamma/scene"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// readManifest decodes the manifest stored in the named file.
+func readManifest(t *testing.T, filename string) Manifest {
+	t.Helper()
+
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		t.Fatalf("Reading manifest failed: %v", err)
+	}
+
+	var manifest Manifest
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		t.Fatalf("Decoding manifest failed: %v", err)
+	}
+	return manifest
+}
+
+func TestWriteManifest(t *testing.T) {
+	dir := t.TempDir()
+	imageName := filepath.Join(dir, "output.jpg")
+	manifestName := filepath.Join(dir, "output.manifest.json")
+
+	s := scene.NewScene()
+	r := NewRenderer(4, 2)
+	r.SetScene(s)
+	r.Render()
+
+	if err := r.Export(imageName, JPEG); err != nil {
+		t.Fatalf("Export failed: %v", err)
+	}
+	if err := r.WriteManifest(manifestName); err != nil {
+		t.Fatalf("WriteManifest failed: %v", err)
+	}
+
+	manifest := readManifest(t, manifestName)
+
+	image, err := os.ReadFile(imageName)
+	if err != nil {
+		t.Fatalf("Reading image failed: %v", err)
+	}
+	sum := sha256.Sum256(image)
+	expected := hex.EncodeToString(sum[:])
+
+	if manifest.OutputSHA256 != expected {
+		t.Errorf("outputSha256 = %s; want %s", manifest.OutputSHA256, expected)
+	}
+	if manifest.SceneHash != s.Hash() {
+		t.Errorf("sceneHash = %s; want %s", manifest.SceneHash, s.Hash())
+	}
+	if manifest.Output != imageName {
+		t.Errorf("output = %s; want %s", manifest.Output, imageName)
+	}
+	if manifest.Format != JPEG {
+		t.Errorf("format = %v; want %v", manifest.Format, JPEG)
+	}
+}
+
+func TestWriteManifestUsesRenderedScene(t *testing.T) {
+	dir := t.TempDir()
+	imageName := filepath.Join(dir, "output.png")
+	manifestName := filepath.Join(dir, "output.manifest.json")
+
+	s := scene.NewScene()
+	r := NewRenderer(4, 2)
+	r.SetScene(s)
+	r.Render()
+
+	if err := r.Export(imageName, PNG); err != nil {
+		t.Fatalf("Export failed: %v", err)
+	}
+
+	// Replacing the scene after rendering must not change what the manifest
+	// says the image was rendered from.
+	r.SetScene(nil)
+	if err := r.WriteManifest(manifestName); err != nil {
+		t.Fatalf("WriteManifest failed: %v", err)
+	}
+
+	manifest := readManifest(t, manifestName)
+	if manifest.SceneHash != s.Hash() {
+		t.Errorf("sceneHash after SetScene = %q; want %q", manifest.SceneHash, s.Hash())
+	}
+}
+
+func TestManifestJSONRoundTrip(t *testing.T) {
+	r := NewRenderer(4, 2)
+	manifest := Manifest{
+		EngineVersion: Version(),
+		CreatedAt:     time.Date(2024, time.June, 20, 12, 0, 0, 0, time.UTC),
+		Output:        "output.jpg",
+		OutputSHA256:  "abc123",
+		Format:        JPEG,
+		SceneHash:     scene.NewScene().Hash(),
+		AssetHashes:   map[string]string{"mesh.obj": "def456"},
+		Settings:      r.Settings(),
+		RenderSeconds: 1.5,
+		Machine:       MachineInfo{Hostname: "node", OS: "linux", Arch: "amd64", NumCPU: 8, GoVersion: "go1.23.4"},
+	}
+
+	data, err := json.Marshal(manifest)
+	if err != nil {
+		t.Fatalf("Marshalling manifest failed: %v", err)
+	}
+
+	var result Manifest
+	if err := json.Unmarshal(data, &result); err != nil {
+		t.Fatalf("Unmarshalling manifest failed: %v", err)
+	}
+	if !reflect.DeepEqual(result, manifest) {
+		t.Errorf("Round trip of manifest = %+v; want %+v", result, manifest)
+	}
+}
+
+func TestWriteManifestBeforeRender(t *testing.T) {
+	r := NewRenderer(4, 2)
+
+	if err := r.WriteManifest(filepath.Join(t.TempDir(), "manifest.json")); err == nil {
+		t.Errorf("WriteManifest before Render succeeded; want an error")
+	}
+}
+
+func TestWriteManifestBeforeExport(t *testing.T) {
+	r := NewRenderer(4, 2)
+	r.Render()
+
+	if err := r.WriteManifest(filepath.Join(t.TempDir(), "manifest.json")); err == nil {
+		t.Errorf("WriteManifest before Export succeeded; want an error")
+	}
+}
@@ -1,15 +1,21 @@
+// Package renderer turns a scene into an image.
+//
+// A Renderer owns the output resolution, the camera viewport, and the pixel
+// buffer. Assign a scene with SetScene, call Render, then Export the result
+// to an image file. Version and Capabilities describe the engine itself.
 package renderer
 
 import (
 	"errors"
 	"fmt"
-	"gamma/geometry"
-	"gamma/scene"
+	"github.com/andrewchu16/bigraytracer/gamma/geometry"
+	"github.com/andrewchu16/bigraytracer/gamma/scene"
 	"image"
 	"image/color"
 	"image/jpeg"
 	"image/png"
 	"os"
+	"time"
 )
 
 type Renderer struct {
@@ -22,6 +28,22 @@ type Renderer struct {
 	scene       *scene.Scene
 	pixelBuffer [][]geometry.Vec3
 	rendered    bool
+	renderTime  time.Duration
+	sceneHash   string // hash of the scene as it was when last rendered
+
+	// exportedFilename and exportedFormat describe the last successful Export
+	// of the current render. exportedFilename is empty if there was none.
+	exportedFilename string
+	exportedFormat   SupportedImageFormats
+}
+
+// Settings describes the effective configuration of a Renderer.
+type Settings struct {
+	ImageWidth     int     `json:"imageWidth"`
+	ImageHeight    int     `json:"imageHeight"`
+	ViewportWidth  float64 `json:"viewportWidth"`
+	ViewportHeight float64 `json:"viewportHeight"`
+	FocalLength    float64 `json:"focalLength"`
 }
 
 func NewRenderer(imgWidth, imgHeight int) Renderer {
@@ -50,9 +72,30 @@ func (r *Renderer) SetScene(scene *scene.Scene) {
 	r.scene = scene
 }
 
+// Settings returns the configuration the renderer will use for the next render.
+func (r *Renderer) Settings() Settings {
+	return Settings{
+		ImageWidth:     r.imgWidth,
+		ImageHeight:    r.imgHeight,
+		ViewportWidth:  r.viewportWidth,
+		ViewportHeight: r.viewportHeight,
+		FocalLength:    r.focalLength,
+	}
+}
+
 func (r *Renderer) Render() {
+	// Remember which scene this render came from, even if SetScene is called later.
+	r.sceneHash = ""
+	if r.scene != nil {
+		r.sceneHash = r.scene.Hash()
+	}
+
+	start := time.Now()
+
 	// do the rendering...
 	r.rendered = true
+	r.renderTime = time.Since(start)
+	r.exportedFilename = ""
 }
 
 func (r *Renderer) Resize(imgWidth, imgHeight int) {
@@ -66,6 +109,7 @@ func (r *Renderer) Resize(imgWidth, imgHeight int) {
 	}
 	r.pixelBuffer = buffer
 	r.rendered = false
+	r.exportedFilename = ""
 }
 
 func (r *Renderer) createImageData() (*image.RGBA, error) {
@@ -133,5 +177,8 @@ func (r *Renderer) Export(filename string, format SupportedImageFormats) error {
 		return err
 	}
 
+	r.exportedFilename = filename
+	r.exportedFormat = format
+
 	return nil
 }
@@ -0,0 +1,65 @@
+package renderer
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestSettingsAfterResize(t *testing.T) {
+	r := NewRenderer(200, 100)
+	r.Resize(300, 150)
+
+	settings := r.Settings()
+	expected := Settings{
+		ImageWidth:     300,
+		ImageHeight:    150,
+		ViewportWidth:  4,
+		ViewportHeight: 2,
+		FocalLength:    1,
+	}
+
+	if settings != expected {
+		t.Errorf("Settings() after Resize(300, 150) = %+v; want %+v", settings, expected)
+	}
+}
+
+func TestSupportedImageFormatsString(t *testing.T) {
+	tests := []struct {
+		format   SupportedImageFormats
+		expected string
+	}{
+		{PNG, "png"},
+		{JPEG, "jpeg"},
+		{SupportedImageFormats(42), "SupportedImageFormats(42)"},
+	}
+
+	for _, test := range tests {
+		if result := test.format.String(); result != test.expected {
+			t.Errorf("String() of format %d = %q; want %q", int(test.format), result, test.expected)
+		}
+	}
+}
+
+func TestSupportedImageFormatsJSON(t *testing.T) {
+	for _, format := range []SupportedImageFormats{PNG, JPEG} {
+		data, err := json.Marshal(format)
+		if err != nil {
+			t.Fatalf("Marshalling %v failed: %v", format, err)
+		}
+
+		var result SupportedImageFormats
+		if err := json.Unmarshal(data, &result); err != nil {
+			t.Errorf("Unmarshalling %s failed: %v", data, err)
+		}
+		if result != format {
+			t.Errorf("Round trip of %v through %s = %v", format, data, result)
+		}
+	}
+
+	for _, name := range []string{`"gif"`, `"PNG"`, `"SupportedImageFormats(42)"`} {
+		var result SupportedImageFormats
+		if err := json.Unmarshal([]byte(name), &result); err == nil {
+			t.Errorf("Unmarshalling %s succeeded with %v; want an error", name, result)
+		}
+	}
+}
@@ -1,8 +1,45 @@
 package renderer
 
+import "fmt"
+
 type SupportedImageFormats int
 
 const (
 	PNG SupportedImageFormats = iota
 	JPEG
-)
\ No newline at end of file
+)
+
+// imageFormats returns every format Export can encode.
+func imageFormats() []SupportedImageFormats {
+	return []SupportedImageFormats{PNG, JPEG}
+}
+
+// String returns the lowercase name of the image format.
+func (f SupportedImageFormats) String() string {
+	switch f {
+	case PNG:
+		return "png"
+	case JPEG:
+		return "jpeg"
+	default:
+		return fmt.Sprintf("SupportedImageFormats(%d)", int(f))
+	}
+}
+
+// MarshalText encodes the image format as its name, so it reads naturally in JSON.
+func (f SupportedImageFormats) MarshalText() ([]byte, error) {
+	return []byte(f.String()), nil
+}
+
+// UnmarshalText decodes an image format from the name produced by String.
+func (f *SupportedImageFormats) UnmarshalText(text []byte) error {
+	switch string(text) {
+	case "png":
+		*f = PNG
+	case "jpeg":
+		*f = JPEG
+	default:
+		return fmt.Errorf("unsupported image format %q", text)
+	}
+	return nil
+}
@@ -0,0 +1,42 @@
+package renderer
+
+import "runtime"
+
+// engineVersion is the version of the gamma engine, following semantic versioning.
+// By convention, each release is tagged gamma/vX.Y.Z with this version.
+const engineVersion = "0.1.0"
+
+// CapabilitySet describes what this build of the engine can do, so front-ends
+// and schedulers can adapt to the binary they are driving.
+type CapabilitySet struct {
+	Version      string                  `json:"version"`
+	ImageFormats []SupportedImageFormats `json:"imageFormats"`
+	Integrators  []string                `json:"integrators"`
+	Accelerators []string                `json:"accelerators"`
+	SIMD         bool                    `json:"simd"`
+	GPU          bool                    `json:"gpu"`
+	OS           string                  `json:"os"`
+	Arch         string                  `json:"arch"`
+	NumCPU       int                     `json:"numCPU"`
+}
+
+// Version returns the version of the gamma engine.
+func Version() string {
+	return engineVersion
+}
+
+// Capabilities reports the features supported by this build of the engine
+// on the current machine.
+func Capabilities() CapabilitySet {
+	return CapabilitySet{
+		Version:      engineVersion,
+		ImageFormats: imageFormats(),
+		Integrators:  []string{},
+		Accelerators: []string{},
+		SIMD:         false, // gamma is pure Go and does not use vector instructions
+		GPU:          false, // gamma renders on the CPU only
+		OS:           runtime.GOOS,
+		Arch:         runtime.GOARCH,
+		NumCPU:       runtime.NumCPU(),
+	}
+}
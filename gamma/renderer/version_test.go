@@ -0,0 +1,58 @@
+package renderer
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestVersionIsSemantic(t *testing.T) {
+	if !regexp.MustCompile(`^\d+\.\d+\.\d+$`).MatchString(Version()) {
+		t.Errorf("Version() = %q; want MAJOR.MINOR.PATCH", Version())
+	}
+}
+
+func TestCapabilitiesImageFormatsExportable(t *testing.T) {
+	r := NewRenderer(4, 2)
+	r.Render()
+	dir := t.TempDir()
+
+	formats := Capabilities().ImageFormats
+	for _, format := range formats {
+		filename := filepath.Join(dir, "output."+format.String())
+		if err := r.Export(filename, format); err != nil {
+			t.Errorf("Export in advertised format %v failed: %v", format, err)
+		}
+	}
+
+	unsupported := SupportedImageFormats(len(formats))
+	if err := r.Export(filepath.Join(dir, "output.bin"), unsupported); err == nil {
+		t.Errorf("Export in unadvertised format %v succeeded; want an error", unsupported)
+	}
+}
+
+func TestCapabilitiesJSON(t *testing.T) {
+	capabilities := Capabilities()
+
+	data, err := json.Marshal(capabilities)
+	if err != nil {
+		t.Fatalf("Marshalling capabilities failed: %v", err)
+	}
+
+	for _, name := range []string{`"png"`, `"jpeg"`} {
+		if !strings.Contains(string(data), name) {
+			t.Errorf("Capabilities JSON %s does not contain %s", data, name)
+		}
+	}
+
+	var result CapabilitySet
+	if err := json.Unmarshal(data, &result); err != nil {
+		t.Fatalf("Unmarshalling capabilities failed: %v", err)
+	}
+	if !reflect.DeepEqual(result, capabilities) {
+		t.Errorf("Round trip of capabilities = %+v; want %+v", result, capabilities)
+	}
+}
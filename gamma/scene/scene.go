@@ -1,8 +1,35 @@
+// Package scene describes what is rendered, along with helpers for placing
+// scene content such as the position of the sun.
 package scene
 
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+)
+
+// hashVersion is written at the start of every scene hash. Bump it whenever the
+// way a scene is hashed changes, so hashes from older builds no longer match.
+const hashVersion = "gamma-scene-v1"
+
 type Scene struct {
 }
 
 func NewScene() *Scene {
 	return &Scene{}
 }
+
+// Hash returns a stable, hex-encoded SHA-256 digest of the scene's contents.
+// Two scenes with the same contents always have the same hash, so it can be used
+// as a cache key or to skip re-rendering a scene that has not changed.
+func (s *Scene) Hash() string {
+	h := sha256.New()
+	s.writeHash(h)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// writeHash writes a canonical encoding of the scene to w. Every field that
+// affects the rendered image must be written here.
+func (s *Scene) writeHash(w io.Writer) {
+	io.WriteString(w, hashVersion)
+}
@@ -0,0 +1,14 @@
+package scene
+
+import "testing"
+
+func TestSceneHashGolden(t *testing.T) {
+	// Changing this value invalidates every cache keyed by scene hashes, so only
+	// update it together with hashVersion.
+	expected := "eb5a35df53cd66c8259a7310a2bd87e9dbcd1490745aa0da1c008c3d6005fd8b"
+
+	result := NewScene().Hash()
+	if result != expected {
+		t.Errorf("NewScene().Hash() = %s; want %s", result, expected)
+	}
+}
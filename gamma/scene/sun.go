@@ -0,0 +1,115 @@
+package scene
+
+import (
+	"github.com/andrewchu16/bigraytracer/gamma/geometry"
+	"math"
+	"time"
+)
+
+// SOLAR_CONSTANT is the solar irradiance at the top of the atmosphere, in W/m².
+const SOLAR_CONSTANT = 1361.0
+
+// SunPosition is the apparent position of the sun in the sky, as seen by an
+// observer at a given place and time. Angles are in degrees.
+type SunPosition struct {
+	// Azimuth is measured clockwise from north, so east is 90 and south is 180.
+	Azimuth float64
+	// Elevation is the angle above the horizon. It is negative at night.
+	Elevation float64
+}
+
+// NewSunPosition computes the position of the sun for an observer at the given
+// latitude and longitude (in degrees, north and east positive) at time t.
+//
+// It uses the NOAA approximation of the solar ephemeris, which is accurate to
+// well under a degree for dates between 1800 and 2100 and degrades slowly
+// outside that range. Atmospheric refraction is not taken into account.
+func NewSunPosition(latitude, longitude float64, t time.Time) SunPosition {
+	t = t.UTC()
+
+	// Unix seconds are used rather than UnixNano, which overflows outside 1678–2262.
+	julianDay := float64(t.Unix())/86400 + float64(t.Nanosecond())/8.64e13 + 2440587.5
+	jc := (julianDay - 2451545) / 36525 // Julian centuries since J2000.0
+
+	meanLong := math.Mod(280.46646+jc*(36000.76983+jc*0.0003032), 360)
+	meanAnom := 357.52911 + jc*(35999.05029-0.0001537*jc)
+	eccentricity := 0.016708634 - jc*(0.000042037+0.0000001267*jc)
+
+	center := sinDeg(meanAnom)*(1.914602-jc*(0.004817+0.000014*jc)) +
+		sinDeg(2*meanAnom)*(0.019993-0.000101*jc) +
+		sinDeg(3*meanAnom)*0.000289
+
+	omega := 125.04 - 1934.136*jc
+	apparentLong := meanLong + center - 0.00569 - 0.00478*sinDeg(omega)
+
+	meanObliquity := 23 + (26+(21.448-jc*(46.815+jc*(0.00059-jc*0.001813)))/60)/60
+	obliquity := meanObliquity + 0.00256*cosDeg(omega)
+
+	declination := degrees(math.Asin(sinDeg(obliquity) * sinDeg(apparentLong)))
+
+	// The equation of time, in minutes, is the offset between apparent and mean solar time.
+	y := math.Pow(math.Tan(radians(obliquity/2)), 2)
+	eqTime := 4 * degrees(y*sinDeg(2*meanLong)-
+		2*eccentricity*sinDeg(meanAnom)+
+		4*eccentricity*y*sinDeg(meanAnom)*cosDeg(2*meanLong)-
+		0.5*y*y*sinDeg(4*meanLong)-
+		1.25*eccentricity*eccentricity*sinDeg(2*meanAnom))
+
+	minutes := float64(t.Hour()*60+t.Minute()) + float64(t.Second())/60 + float64(t.Nanosecond())/6e10
+	trueSolarTime := math.Mod(minutes+eqTime+4*longitude, 1440)
+	if trueSolarTime < 0 {
+		trueSolarTime += 1440
+	}
+	hourAngle := trueSolarTime/4 - 180
+
+	cosZenith := sinDeg(latitude)*sinDeg(declination) +
+		cosDeg(latitude)*cosDeg(declination)*cosDeg(hourAngle)
+	elevation := 90 - degrees(math.Acos(math.Max(-1, math.Min(1, cosZenith))))
+
+	azimuth := degrees(math.Atan2(
+		sinDeg(hourAngle),
+		cosDeg(hourAngle)*sinDeg(latitude)-math.Tan(radians(declination))*cosDeg(latitude),
+	)) + 180
+
+	return SunPosition{Azimuth: math.Mod(azimuth, 360), Elevation: elevation}
+}
+
+// Direction returns the unit vector pointing from the scene towards the sun.
+// The scene is oriented with +Y up, +X east, and -Z north.
+func (p SunPosition) Direction() geometry.Vec3 {
+	return geometry.NewVec3(
+		cosDeg(p.Elevation)*sinDeg(p.Azimuth),
+		sinDeg(p.Elevation),
+		-cosDeg(p.Elevation)*cosDeg(p.Azimuth),
+	)
+}
+
+// Irradiance returns the approximate direct normal irradiance of sunlight at
+// ground level, in W/m², for a clear sky. It is zero when the sun is below the
+// horizon.
+func (p SunPosition) Irradiance() float64 {
+	if p.Elevation <= 0 {
+		return 0
+	}
+
+	// Kasten–Young air mass with the Meinel attenuation model.
+	zenith := 90 - p.Elevation
+	airMass := 1 / (cosDeg(zenith) + 0.50572*math.Pow(96.07995-zenith, -1.6364))
+	return SOLAR_CONSTANT * math.Pow(0.7, math.Pow(airMass, 0.678))
+}
+
+func radians(deg float64) float64 {
+	return deg * math.Pi / 180
+}
+
+func degrees(rad float64) float64 {
+	return rad * 180 / math.Pi
+}
+
+func sinDeg(deg float64) float64 {
+	return math.Sin(radians(deg))
+}
+
+func cosDeg(deg float64) float64 {
+	return math.Cos(radians(deg))
+}
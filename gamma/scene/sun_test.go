@@ -0,0 +1,80 @@
+package scene
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestSunPositionSolsticeNoon(t *testing.T) {
+	// Solar noon at Greenwich on the 2024 June solstice.
+	when := time.Date(2024, time.June, 20, 12, 2, 0, 0, time.UTC)
+	p := NewSunPosition(51.4769, 0, when)
+
+	expected := 90 - 51.4769 + 23.44
+	if math.Abs(p.Elevation-expected) > 0.5 {
+		t.Errorf("Elevation = %f; want %f", p.Elevation, expected)
+	}
+	if math.Abs(p.Azimuth-180) > 2 {
+		t.Errorf("Azimuth = %f; want 180", p.Azimuth)
+	}
+}
+
+func TestSunPositionEquinoxEquator(t *testing.T) {
+	// Around solar noon on the equator at an equinox the sun is nearly overhead.
+	when := time.Date(2024, time.March, 20, 12, 7, 0, 0, time.UTC)
+	p := NewSunPosition(0, 0, when)
+
+	if p.Elevation < 89 {
+		t.Errorf("Elevation = %f; want nearly 90", p.Elevation)
+	}
+}
+
+func TestSunPositionOutsideUnixNanoRange(t *testing.T) {
+	// Solar noon in Boulder on the 2300 June solstice, past the int64 nanosecond range.
+	when := time.Date(2300, time.June, 21, 19, 3, 0, 0, time.UTC)
+	p := NewSunPosition(40.015, -105.27, when)
+
+	expected := 90 - 40.015 + 23.43
+	if math.Abs(p.Elevation-expected) > 0.5 {
+		t.Errorf("Elevation = %f; want %f", p.Elevation, expected)
+	}
+	if math.Abs(p.Azimuth-180) > 2 {
+		t.Errorf("Azimuth = %f; want 180", p.Azimuth)
+	}
+}
+
+func TestSunPositionLongitudeShift(t *testing.T) {
+	// Sunrise in the east: six hours before solar noon at 90°E the sun is near the horizon.
+	when := time.Date(2024, time.March, 20, 0, 7, 0, 0, time.UTC)
+	p := NewSunPosition(0, 90, when)
+
+	if math.Abs(p.Elevation) > 1 {
+		t.Errorf("Elevation = %f; want close to 0", p.Elevation)
+	}
+	if math.Abs(p.Azimuth-90) > 1 {
+		t.Errorf("Azimuth = %f; want close to 90", p.Azimuth)
+	}
+}
+
+func TestSunDirectionAndIrradiance(t *testing.T) {
+	overhead := SunPosition{Azimuth: 0, Elevation: 90}
+	dir := overhead.Direction()
+	if math.Abs(dir.Y-1) > 1e-9 || !dir.IsNormalized() {
+		t.Errorf("Direction of overhead sun = %v; want (0, 1, 0)", dir)
+	}
+
+	east := SunPosition{Azimuth: 90, Elevation: 0}.Direction()
+	if math.Abs(east.X-1) > 1e-9 {
+		t.Errorf("Direction of sun on the eastern horizon = %v; want (1, 0, 0)", east)
+	}
+
+	if irradiance := overhead.Irradiance(); irradiance < 900 || irradiance > SOLAR_CONSTANT {
+		t.Errorf("Irradiance of overhead sun = %f; want between 900 and %f", irradiance, SOLAR_CONSTANT)
+	}
+
+	night := SunPosition{Azimuth: 0, Elevation: -10}
+	if irradiance := night.Irradiance(); irradiance != 0 {
+		t.Errorf("Irradiance at night = %f; want 0", irradiance)
+	}
+}